@@ -0,0 +1,114 @@
+package account
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+
+	"chain/core/txbuilder"
+	"chain/database/pg"
+	chainjson "chain/encoding/json"
+	"chain/errors"
+	"chain/protocol/bc"
+)
+
+// ErrNoUTXO is returned when a spend_account_unspent_output action names
+// an outpoint that is not present, unspent, in the account UTXO index.
+var ErrNoUTXO = errors.New("unspent output not found for account")
+
+func init() {
+	decoders["spend_account_unspent_output"] = decodeSpendUTXOAction
+}
+
+func decodeSpendUTXOAction(data []byte) (txbuilder.Action, error) {
+	a := new(SpendUTXOAction)
+	err := json.Unmarshal(data, a)
+	return a, err
+}
+
+// SpendUTXOAction spends a specific, already-known unspent output
+// belonging to an account. Unlike SpendAction, it does not consult the
+// reserver or coin-selection logic: the caller names the exact outpoint
+// to spend, and the action fails if that output is not present in the
+// account UTXO index or has already been spent.
+type SpendUTXOAction struct {
+	Params struct {
+		TxHash *bc.Hash `json:"transaction_id"`
+		TxOut  *uint32  `json:"position"`
+	}
+	ReferenceData chainjson.Map `json:"reference_data"`
+}
+
+func (a *SpendUTXOAction) Build(ctx context.Context, b *txbuilder.TemplateBuilder) error {
+	var outpoint bc.Outpoint
+	if a.Params.TxHash != nil {
+		outpoint.Hash = *a.Params.TxHash
+	}
+	if a.Params.TxOut != nil {
+		outpoint.Index = *a.Params.TxOut
+	}
+
+	u, err := loadAccountUTXO(ctx, outpoint)
+	if err != nil {
+		return errors.Wrap(err, "loading account utxo")
+	}
+
+	acc, err := findByID(ctx, u.AccountID)
+	if err != nil {
+		return errors.Wrap(err, "loading account for utxo")
+	}
+
+	txInput, sigInst, err := utxoToInputs(ctx, acc, u, a.ReferenceData)
+	if err != nil {
+		return errors.Wrap(err, "creating inputs")
+	}
+
+	return b.AddInput(txInput, sigInst)
+}
+
+// UTXOOwner returns the ID of the account that owns the named unspent
+// output and the asset/amount it holds, for callers (such as test
+// fixtures) that need to build a balanced transaction around a
+// SpendUTXOAction.
+func UTXOOwner(ctx context.Context, outpoint bc.Outpoint) (accountID string, assetAmount bc.AssetAmount, err error) {
+	u, err := loadAccountUTXO(ctx, outpoint)
+	if err != nil {
+		return "", bc.AssetAmount{}, err
+	}
+	return u.AccountID, u.AssetAmount, nil
+}
+
+// accountUTXO is the subset of the account_utxos row needed to spend
+// a known output.
+type accountUTXO struct {
+	bc.Outpoint
+	bc.AssetAmount
+	AccountID      string
+	ControlProgram []byte
+}
+
+// loadAccountUTXO looks up a single unspent output owned by an account.
+// It returns an error if the output does not exist in the UTXO index or
+// has already been spent.
+func loadAccountUTXO(ctx context.Context, outpoint bc.Outpoint) (*accountUTXO, error) {
+	const q = `
+		SELECT account_id, control_program, asset_id, amount
+		FROM account_utxos
+		WHERE tx_hash = $1 AND index = $2 AND NOT spent
+	`
+	var u accountUTXO
+	err := pg.FromContext(ctx).QueryRow(ctx, q, outpoint.Hash, outpoint.Index).Scan(
+		&u.AccountID,
+		&u.ControlProgram,
+		&u.AssetID,
+		&u.Amount,
+	)
+	if err == sql.ErrNoRows {
+		return nil, errors.WithDetailf(ErrNoUTXO, "unspent output %s not found for an account", outpoint)
+	}
+	if err != nil {
+		return nil, err
+	}
+	u.Outpoint = outpoint
+	return &u, nil
+}
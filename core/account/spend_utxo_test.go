@@ -0,0 +1,59 @@
+package account
+
+import (
+	"testing"
+
+	"chain/protocol/bc"
+)
+
+func TestDecodeSpendUTXOAction(t *testing.T) {
+	const data = `{
+		"transaction_id": "0100000000000000000000000000000000000000000000000000000000000000",
+		"position": 1,
+		"reference_data": {"foo": "bar"}
+	}`
+
+	act, err := decodeSpendUTXOAction([]byte(data))
+	if err != nil {
+		t.Fatalf("decodeSpendUTXOAction: %v", err)
+	}
+
+	a, ok := act.(*SpendUTXOAction)
+	if !ok {
+		t.Fatalf("decodeSpendUTXOAction returned %T, want *SpendUTXOAction", act)
+	}
+
+	if a.Params.TxHash == nil {
+		t.Fatal("Params.TxHash = nil, want a decoded hash")
+	}
+	var want bc.Hash
+	if err := want.UnmarshalText([]byte("0100000000000000000000000000000000000000000000000000000000000000")); err != nil {
+		t.Fatal(err)
+	}
+	if *a.Params.TxHash != want {
+		t.Errorf("Params.TxHash = %s, want %s", a.Params.TxHash, want)
+	}
+
+	if a.Params.TxOut == nil || *a.Params.TxOut != 1 {
+		t.Errorf("Params.TxOut = %v, want 1", a.Params.TxOut)
+	}
+
+	if a.ReferenceData["foo"] != "bar" {
+		t.Errorf("ReferenceData[foo] = %v, want bar", a.ReferenceData["foo"])
+	}
+}
+
+func TestDecodeSpendUTXOActionMissingFields(t *testing.T) {
+	act, err := decodeSpendUTXOAction([]byte(`{}`))
+	if err != nil {
+		t.Fatalf("decodeSpendUTXOAction: %v", err)
+	}
+
+	a := act.(*SpendUTXOAction)
+	if a.Params.TxHash != nil {
+		t.Errorf("Params.TxHash = %v, want nil", a.Params.TxHash)
+	}
+	if a.Params.TxOut != nil {
+		t.Errorf("Params.TxOut = %v, want nil", a.Params.TxOut)
+	}
+}
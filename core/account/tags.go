@@ -0,0 +1,39 @@
+package account
+
+import (
+	"context"
+
+	"chain/database/pg"
+	"chain/errors"
+)
+
+// UpdateTags replaces the tags on the account identified by idOrAlias
+// (an account ID or alias) with tags, persists the change, and
+// invalidates any cached copy of the account. It returns the updated
+// account.
+//
+// UpdateTags replaces rather than merges: callers that want to add or
+// remove individual tags must read the account's current tags first
+// and compute the full replacement map themselves. This matches
+// Create, which also takes tags as a complete map rather than a patch.
+func UpdateTags(ctx context.Context, idOrAlias string, tags map[string]interface{}) (*Account, error) {
+	acc, err := findByIDOrAlias(ctx, idOrAlias)
+	if err != nil {
+		return nil, errors.Wrap(err, "finding account")
+	}
+
+	tagsParam, err := mapToNullString(tags)
+	if err != nil {
+		return nil, errors.Wrap(err, "serializing tags")
+	}
+
+	const q = `UPDATE accounts SET tags = $1 WHERE id = $2`
+	_, err = pg.FromContext(ctx).Exec(ctx, q, tagsParam, acc.ID)
+	if err != nil {
+		return nil, errors.Wrap(err, "updating tags")
+	}
+
+	acc.Tags = tags
+	cache.delete(acc.ID)
+	return acc, nil
+}
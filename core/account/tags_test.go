@@ -0,0 +1,52 @@
+package account
+
+import (
+	"testing"
+
+	"chain/database/pg/pgtest"
+	"chain/testutil"
+)
+
+func TestUpdateTags(t *testing.T) {
+	ctx, cleanup := pgtest.NewContext(t)
+	defer cleanup()
+
+	keys := []string{testutil.TestXPub.String()}
+	acc, err := Create(ctx, keys, 1, "", map[string]interface{}{"foo": "bar", "stale": "x"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newTags := map[string]interface{}{"foo": "baz", "quux": float64(1)}
+	updated, err := UpdateTags(ctx, acc.ID, newTags)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if updated.ID != acc.ID {
+		t.Errorf("UpdateTags returned account %s, want %s", updated.ID, acc.ID)
+	}
+	if updated.Tags["foo"] != "baz" || updated.Tags["quux"] != float64(1) {
+		t.Errorf("UpdateTags returned tags %v, want %v", updated.Tags, newTags)
+	}
+
+	// UpdateTags replaces rather than merges: the old "foo" value and
+	// any tags not named in newTags must be gone.
+	refetched, err := findByIDOrAlias(ctx, acc.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(refetched.Tags) != len(newTags) {
+		t.Errorf("UpdateTags tags = %v, want exactly %v", refetched.Tags, newTags)
+	}
+}
+
+func TestUpdateTagsUnknownAccount(t *testing.T) {
+	ctx, cleanup := pgtest.NewContext(t)
+	defer cleanup()
+
+	_, err := UpdateTags(ctx, "nonexistent", map[string]interface{}{"foo": "bar"})
+	if err == nil {
+		t.Fatal("UpdateTags(unknown account) = nil error, want an error")
+	}
+}
@@ -13,7 +13,6 @@ import (
 	"chain/core/txbuilder"
 	"chain/database/pg"
 	"chain/encoding/json"
-	"chain/errors"
 	"chain/protocol"
 	"chain/protocol/bc"
 	"chain/protocol/mempool"
@@ -36,6 +35,14 @@ func CreateAccountFixture(ctx context.Context, t testing.TB, keys []string, quor
 	return acc.ID
 }
 
+func UpdateAccountTagsFixture(ctx context.Context, t testing.TB, idOrAlias string, tags map[string]interface{}) *account.Account {
+	acc, err := account.UpdateTags(ctx, idOrAlias, tags)
+	if err != nil {
+		testutil.FatalErr(t, err)
+	}
+	return acc
+}
+
 func CreateAccountControlProgramFixture(ctx context.Context, t testing.TB, accID string) []byte {
 	if accID == "" {
 		accID = CreateAccountFixture(ctx, t, nil, 0, "", nil)
@@ -132,45 +139,41 @@ func InitializeSigningGenerator(ctx context.Context, store protocol.Store, pool
 }
 
 func Issue(ctx context.Context, t testing.TB, fc *protocol.FC, assetID bc.AssetID, amount uint64, actions []txbuilder.Action) *bc.Tx {
+	return issue(ctx, t, fc, assetID, amount, actions, "")
+}
+
+// IssueWithClientToken is Issue, made idempotent by clientToken: a
+// retry using the same non-empty token within the idempotency window
+// returns the result of the original call instead of re-issuing.
+func IssueWithClientToken(ctx context.Context, t testing.TB, fc *protocol.FC, assetID bc.AssetID, amount uint64, actions []txbuilder.Action, clientToken string) *bc.Tx {
+	return issue(ctx, t, fc, assetID, amount, actions, clientToken)
+}
+
+func issue(ctx context.Context, t testing.TB, fc *protocol.FC, assetID bc.AssetID, amount uint64, actions []txbuilder.Action, clientToken string) *bc.Tx {
 	assetAmount := bc.AssetAmount{AssetID: assetID, Amount: amount}
 	actions = append(actions, NewIssueAction(assetAmount, nil))
 
-	txTemplate, err := txbuilder.Build(
-		ctx,
-		nil,
-		actions,
-		nil,
-	)
-	if err != nil {
-		t.Log(errors.Stack(err))
-		t.Fatal(err)
-	}
-	SignTxTemplate(t, txTemplate, nil)
-	tx, err := txbuilder.FinalizeTx(ctx, fc, txTemplate)
-	if err != nil {
-		t.Log(errors.Stack(err))
-		t.Fatal(err)
-	}
-
-	return tx
+	gen := &TxGenerator{FC: fc}
+	return gen.buildAndSubmit(ctx, t, actions, clientToken, func(t testing.TB, tpl *txbuilder.Template) {
+		SignTxTemplate(t, tpl, nil)
+	})
 }
 
 func Transfer(ctx context.Context, t testing.TB, fc *protocol.FC, actions []txbuilder.Action) *bc.Tx {
-	template, err := txbuilder.Build(ctx, nil, actions, nil)
-	if err != nil {
-		t.Log(errors.Stack(err))
-		t.Fatal(err)
-	}
-
-	SignTxTemplate(t, template, testutil.TestXPrv)
+	return transfer(ctx, t, fc, actions, "")
+}
 
-	tx, err := txbuilder.FinalizeTx(ctx, fc, template)
-	if err != nil {
-		t.Log(errors.Stack(err))
-		t.Fatal(err)
-	}
+// TransferWithClientToken is Transfer, made idempotent by clientToken,
+// as in IssueWithClientToken.
+func TransferWithClientToken(ctx context.Context, t testing.TB, fc *protocol.FC, actions []txbuilder.Action, clientToken string) *bc.Tx {
+	return transfer(ctx, t, fc, actions, clientToken)
+}
 
-	return tx
+func transfer(ctx context.Context, t testing.TB, fc *protocol.FC, actions []txbuilder.Action, clientToken string) *bc.Tx {
+	gen := &TxGenerator{FC: fc}
+	return gen.buildAndSubmit(ctx, t, actions, clientToken, func(t testing.TB, tpl *txbuilder.Template) {
+		SignTxTemplate(t, tpl, testutil.TestXPrv)
+	})
 }
 
 func NewIssueAction(assetAmount bc.AssetAmount, referenceData json.Map) *asset.IssueAction {
@@ -202,6 +205,41 @@ func NewAccountSpendAction(amt bc.AssetAmount, accountID string, txHash *bc.Hash
 	}
 }
 
+// NewSpendUTXOFixture builds a transaction that spends the single
+// output named by outpoint, bypassing the reserver/coin-selection logic
+// used by SpendAction. It's useful for deterministically spending a
+// specific output produced by IssueAssetsFixture.
+func NewSpendUTXOFixture(ctx context.Context, t testing.TB, fc *protocol.FC, outpoint bc.Outpoint) *bc.Tx {
+	accountID, assetAmount, err := account.UTXOOwner(ctx, outpoint)
+	if err != nil {
+		testutil.FatalErr(t, err)
+	}
+
+	src := NewSpendUTXOAction(outpoint, nil)
+	dest := NewAccountControlAction(assetAmount, accountID, nil)
+	tpl, err := txbuilder.Build(ctx, nil, []txbuilder.Action{src, dest}, nil)
+	if err != nil {
+		testutil.FatalErr(t, err)
+	}
+
+	SignTxTemplate(t, tpl, testutil.TestXPrv)
+
+	tx, err := txbuilder.FinalizeTx(ctx, fc, tpl)
+	if err != nil {
+		testutil.FatalErr(t, err)
+	}
+
+	return tx
+}
+
+func NewSpendUTXOAction(outpoint bc.Outpoint, refData json.Map) *account.SpendUTXOAction {
+	a := new(account.SpendUTXOAction)
+	a.Params.TxHash = &outpoint.Hash
+	a.Params.TxOut = &outpoint.Index
+	a.ReferenceData = refData
+	return a
+}
+
 func NewAccountControlAction(amt bc.AssetAmount, accountID string, refData json.Map) *account.ControlAction {
 	return &account.ControlAction{
 		Params: struct {
@@ -211,3 +249,13 @@ func NewAccountControlAction(amt bc.AssetAmount, accountID string, refData json.
 		ReferenceData: refData,
 	}
 }
+
+// NewControlAddressAction builds a control action that pays amt to an
+// external address rather than to a locally managed account.
+func NewControlAddressAction(amt bc.AssetAmount, address string, refData json.Map) *txbuilder.ControlAddressAction {
+	a := new(txbuilder.ControlAddressAction)
+	a.Params.AssetAmount = amt
+	a.Params.Address = address
+	a.ReferenceData = refData
+	return a
+}
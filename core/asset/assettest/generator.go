@@ -0,0 +1,153 @@
+package assettest
+
+import (
+	"context"
+	"testing"
+
+	"chain/core/mockhsm"
+	"chain/core/txbuilder"
+	"chain/protocol"
+	"chain/protocol/bc"
+	"chain/testutil"
+)
+
+// TxGenerator bundles the FC, HSM, and default signing key that the
+// free functions in this package (Issue, Transfer, and friends) thread
+// through every call individually. For tests that build several
+// transactions in sequence — multi-input/multi-output scenarios, or
+// benchmarks that issue many transactions against one FC — holding
+// these in one place removes the repetition and lets the caller build
+// up a transaction's actions across several method calls before
+// finalizing it:
+//
+//	gen := assettest.NewTxGenerator(fc, hsm)
+//	acc := gen.CreateAccount(ctx, t, 1, "", nil)
+//	gen.Issue(assetID, 100, acc)
+//	tx := gen.Finalize(ctx, t)
+//
+// The free functions Issue and Transfer build their one-off
+// transaction through a TxGenerator too (see issue/transfer in
+// fixtures.go), so the build/sign/submit pipeline has one
+// implementation, not two. They construct their own TxGenerator per
+// call rather than sharing one package-level value: each call carries
+// its own *protocol.FC (a different one per test), and a shared
+// mutable package variable would let unrelated tests' pending actions
+// bleed into each other's transactions.
+//
+// This type deliberately doesn't hold a *txbuilder.TemplateBuilder or
+// asset/account manager objects. A TemplateBuilder is the argument
+// Action.Build populates, not something a caller accumulates actions
+// into up front; and this package's asset/account APIs (account.Create,
+// asset.Define, ...) are package-level functions rather than methods on
+// a manager value, so there's no manager instance to cache.
+//
+// A TxGenerator is not safe for concurrent use.
+type TxGenerator struct {
+	FC  *protocol.FC
+	HSM *mockhsm.HSM
+
+	keys    []string
+	actions []txbuilder.Action
+}
+
+// NewTxGenerator returns a TxGenerator backed by fc and hsm. If hsm is
+// nil, a new in-memory HSM is created. Like the free functions in this
+// package, a TxGenerator with no keys of its own signs with
+// testutil.TestXPrv and defaults new accounts and assets to
+// testutil.TestXPub.
+func NewTxGenerator(fc *protocol.FC, hsm *mockhsm.HSM) *TxGenerator {
+	if hsm == nil {
+		hsm = mockhsm.New(nil)
+	}
+	return &TxGenerator{FC: fc, HSM: hsm}
+}
+
+// CreateKey generates a new key in the generator's HSM and adds it to
+// the set of keys used by CreateAccount and DefineAsset. Transactions
+// built with keys added this way cannot be signed by Finalize, which
+// only knows testutil.TestXPrv; use it for quorum/multi-key scenarios
+// that are signed out of band.
+func (g *TxGenerator) CreateKey(ctx context.Context, t testing.TB) string {
+	xpub, err := g.HSM.CreateKey(ctx, "")
+	if err != nil {
+		testutil.FatalErr(t, err)
+	}
+	key := xpub.XPub.String()
+	g.keys = append(g.keys, key)
+	return key
+}
+
+// CreateAccount creates an account controlled by the generator's keys,
+// or by testutil.TestXPub if CreateKey has not been called.
+func (g *TxGenerator) CreateAccount(ctx context.Context, t testing.TB, quorum int, alias string, tags map[string]interface{}) string {
+	return CreateAccountFixture(ctx, t, g.keys, quorum, alias, tags)
+}
+
+// DefineAsset defines an asset issuable by the generator's keys, or by
+// testutil.TestXPub if CreateKey has not been called.
+func (g *TxGenerator) DefineAsset(ctx context.Context, t testing.TB, quorum int, def map[string]interface{}, alias string, tags map[string]interface{}) bc.AssetID {
+	return CreateAssetFixture(ctx, t, g.keys, quorum, def, alias, tags)
+}
+
+// Issue appends an issuance of amount units of assetID, controlled by
+// accountID, to the transaction under construction. Call Finalize to
+// build, sign, and submit it.
+func (g *TxGenerator) Issue(assetID bc.AssetID, amount uint64, accountID string) {
+	assetAmount := bc.AssetAmount{AssetID: assetID, Amount: amount}
+	g.actions = append(g.actions, NewIssueAction(assetAmount, nil), NewAccountControlAction(assetAmount, accountID, nil))
+}
+
+// Spend appends a spend of amount units of assetID from accountID's
+// reserved UTXOs to the transaction under construction.
+func (g *TxGenerator) Spend(assetID bc.AssetID, amount uint64, accountID string) {
+	assetAmount := bc.AssetAmount{AssetID: assetID, Amount: amount}
+	g.actions = append(g.actions, NewAccountSpendAction(assetAmount, accountID, nil, nil, nil))
+}
+
+// Transfer appends a spend of amount units of assetID from srcAccountID
+// and a matching control of destAccountID to the transaction under
+// construction.
+func (g *TxGenerator) Transfer(assetID bc.AssetID, amount uint64, srcAccountID, destAccountID string) {
+	g.Spend(assetID, amount, srcAccountID)
+	g.actions = append(g.actions, NewAccountControlAction(bc.AssetAmount{AssetID: assetID, Amount: amount}, destAccountID, nil))
+}
+
+// Finalize builds the actions accumulated since the last Finalize or
+// Reset into a single transaction, signs it with testutil.TestXPrv,
+// submits it to the FC, and resets the generator so the next call
+// starts a fresh transaction. ctx is forwarded to the build/submit
+// pipeline unchanged, the same as CreateAccount, DefineAsset, and
+// CreateKey — callers relying on a DB-backed ctx (as account.Create and
+// asset.Define require) need that to reach txbuilder.Build too.
+func (g *TxGenerator) Finalize(ctx context.Context, t testing.TB) *bc.Tx {
+	sign := func(t testing.TB, tpl *txbuilder.Template) { SignTxTemplate(t, tpl, testutil.TestXPrv) }
+	tx := g.buildAndSubmit(ctx, t, g.actions, "", sign)
+	g.Reset()
+	return tx
+}
+
+// buildAndSubmit is the build/sign/submit pipeline shared by Finalize
+// and the free functions issue and transfer in fixtures.go: build the
+// given actions into a template (honoring clientToken if non-empty),
+// run sign over the result, then submit it to g.FC.
+func (g *TxGenerator) buildAndSubmit(ctx context.Context, t testing.TB, actions []txbuilder.Action, clientToken string, sign func(testing.TB, *txbuilder.Template)) *bc.Tx {
+	tpl, err := txbuilder.BuildWithClientToken(ctx, nil, actions, nil, clientToken)
+	if err != nil {
+		testutil.FatalErr(t, err)
+	}
+
+	sign(t, tpl)
+
+	tx, err := txbuilder.FinalizeTxWithClientToken(ctx, g.FC, tpl, clientToken)
+	if err != nil {
+		testutil.FatalErr(t, err)
+	}
+
+	return tx
+}
+
+// Reset discards the actions accumulated since the last Finalize,
+// without touching the generator's keys, FC, or HSM.
+func (g *TxGenerator) Reset() {
+	g.actions = nil
+}
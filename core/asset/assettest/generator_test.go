@@ -0,0 +1,58 @@
+package assettest
+
+import (
+	"testing"
+
+	"chain/database/pg/pgtest"
+)
+
+func TestTxGeneratorIssueAndTransfer(t *testing.T) {
+	ctx, cleanup := pgtest.NewContext(t)
+	defer cleanup()
+
+	fc, _, err := InitializeSigningGenerator(ctx, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewTxGenerator(fc, nil)
+	alice := gen.CreateAccount(ctx, t, 1, "", nil)
+	bob := gen.CreateAccount(ctx, t, 1, "", nil)
+	assetID := gen.DefineAsset(ctx, t, 1, nil, "", nil)
+
+	gen.Issue(assetID, 100, alice)
+	issueTx := gen.Finalize(ctx, t)
+	if len(issueTx.Outputs) == 0 {
+		t.Fatal("Finalize returned a transaction with no outputs")
+	}
+
+	gen.Transfer(assetID, 10, alice, bob)
+	transferTx := gen.Finalize(ctx, t)
+	if len(transferTx.Inputs) == 0 {
+		t.Fatal("Finalize returned a transaction with no inputs")
+	}
+}
+
+func TestTxGeneratorResetDiscardsActions(t *testing.T) {
+	ctx, cleanup := pgtest.NewContext(t)
+	defer cleanup()
+
+	fc, _, err := InitializeSigningGenerator(ctx, nil, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	gen := NewTxGenerator(fc, nil)
+	alice := gen.CreateAccount(ctx, t, 1, "", nil)
+	assetID := gen.DefineAsset(ctx, t, 1, nil, "", nil)
+
+	gen.Issue(assetID, 100, alice)
+	if len(gen.actions) == 0 {
+		t.Fatal("Issue did not accumulate any actions")
+	}
+
+	gen.Reset()
+	if len(gen.actions) != 0 {
+		t.Fatalf("actions after Reset = %v, want none", gen.actions)
+	}
+}
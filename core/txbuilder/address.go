@@ -0,0 +1,46 @@
+package txbuilder
+
+import (
+	"chain/crypto/ripemd160"
+	"chain/encoding/base58"
+	"chain/encoding/bech32"
+	"chain/errors"
+)
+
+// ErrBadAddress is returned by decodeAddress when the address string is
+// not valid base58check or bech32, does not encode a 20-byte pubkey
+// hash, or (for base58check) carries the wrong version byte.
+var ErrBadAddress = errors.New("invalid address")
+
+// addressVersionP2PKH is the base58check version byte this Core uses
+// for pay-to-pubkey-hash addresses.
+const addressVersionP2PKH byte = 0x00
+
+// decodeAddress parses addr as either a base58check-encoded or a
+// bech32-encoded address and returns the 20-byte pubkey hash it
+// commits to. base58check is tried first; addr is only treated as
+// bech32 if it fails to decode as base58check, since a well-formed
+// base58check string can otherwise look like a plausible bech32 one
+// (both alphabets overlap heavily, e.g. on digits and lower-case
+// letters).
+func decodeAddress(addr string) ([]byte, error) {
+	decoded, version, err := base58.CheckDecode(addr)
+	if err == nil {
+		if version != addressVersionP2PKH {
+			return nil, errors.WithDetailf(ErrBadAddress, "version byte %#x, want %#x", version, addressVersionP2PKH)
+		}
+		if len(decoded) != ripemd160.Size {
+			return nil, errors.WithDetailf(ErrBadAddress, "decoded length %d, want %d", len(decoded), ripemd160.Size)
+		}
+		return decoded, nil
+	}
+
+	_, data, err := bech32.Decode(addr)
+	if err != nil {
+		return nil, errors.Wrap(ErrBadAddress, err.Error())
+	}
+	if len(data) != ripemd160.Size {
+		return nil, errors.WithDetailf(ErrBadAddress, "decoded length %d, want %d", len(data), ripemd160.Size)
+	}
+	return data, nil
+}
@@ -0,0 +1,86 @@
+package txbuilder
+
+import (
+	"bytes"
+	"testing"
+
+	"chain/encoding/base58"
+	"chain/encoding/bech32"
+	"chain/errors"
+)
+
+func TestDecodeAddressBase58(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x01}, 20)
+	addr := base58.CheckEncode(addressVersionP2PKH, hash)
+
+	got, err := decodeAddress(addr)
+	if err != nil {
+		t.Fatalf("decodeAddress(%q) = _, %v, want nil error", addr, err)
+	}
+	if !bytes.Equal(got, hash) {
+		t.Errorf("decodeAddress(%q) = %x, want %x", addr, got, hash)
+	}
+}
+
+func TestDecodeAddressBase58WrongVersion(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x02}, 20)
+	addr := base58.CheckEncode(addressVersionP2PKH+1, hash)
+
+	_, err := decodeAddress(addr)
+	if errors.Root(err) != ErrBadAddress {
+		t.Fatalf("decodeAddress(%q) err = %v, want ErrBadAddress", addr, err)
+	}
+}
+
+func TestDecodeAddressBase58BadChecksum(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x03}, 20)
+	addr := base58.CheckEncode(addressVersionP2PKH, hash)
+	addr = addr[:len(addr)-1] + "z" // corrupt the checksum
+
+	if _, err := decodeAddress(addr); err == nil {
+		t.Fatalf("decodeAddress(%q) = _, nil, want an error", addr)
+	}
+}
+
+func TestDecodeAddressBech32(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x04}, 20)
+	addr, err := bech32.Encode("bc", hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := decodeAddress(addr)
+	if err != nil {
+		t.Fatalf("decodeAddress(%q) = _, %v, want nil error", addr, err)
+	}
+	if !bytes.Equal(got, hash) {
+		t.Errorf("decodeAddress(%q) = %x, want %x", addr, got, hash)
+	}
+}
+
+func TestDecodeAddressBech32BadChecksum(t *testing.T) {
+	hash := bytes.Repeat([]byte{0x05}, 20)
+	addr, err := bech32.Encode("bc", hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+	addr = addr[:len(addr)-1] + "q" // corrupt the checksum; not valid base58check either
+
+	if _, err := decodeAddress(addr); err == nil {
+		t.Fatalf("decodeAddress(%q) = _, nil, want an error", addr)
+	}
+}
+
+func TestDecodeAddressWrongLength(t *testing.T) {
+	addr := base58.CheckEncode(addressVersionP2PKH, []byte{0x01, 0x02, 0x03})
+
+	if _, err := decodeAddress(addr); err == nil {
+		t.Fatalf("decodeAddress(%q) = _, nil, want an error", addr)
+	}
+}
+
+func TestDecodeAddressGarbage(t *testing.T) {
+	if _, err := decodeAddress("not a valid address"); err == nil {
+		t.Fatal("decodeAddress(garbage) = _, nil, want an error")
+	}
+}
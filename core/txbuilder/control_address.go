@@ -0,0 +1,51 @@
+package txbuilder
+
+import (
+	"context"
+	"encoding/json"
+
+	chainjson "chain/encoding/json"
+	"chain/errors"
+	"chain/protocol/bc"
+	"chain/protocol/vm/vmutil"
+)
+
+// ControlAddressAction sends an asset amount to an external address
+// rather than to a locally managed account. It's the building block for
+// payments to receivers outside this Core.
+type ControlAddressAction struct {
+	Params struct {
+		bc.AssetAmount
+		Address string `json:"address"`
+	}
+	ReferenceData chainjson.Map `json:"reference_data"`
+}
+
+func (a *ControlAddressAction) Build(ctx context.Context, b *TemplateBuilder) error {
+	hash, err := decodeAddress(a.Params.Address)
+	if err != nil {
+		return errors.WithDetailf(ErrBadAddress, "%q: %s", a.Params.Address, err)
+	}
+
+	program, err := vmutil.P2PKHProgram(hash)
+	if err != nil {
+		return errors.Wrap(err, "building control program")
+	}
+
+	out := &bc.TxOutput{
+		AssetAmount:    a.Params.AssetAmount,
+		ControlProgram: program,
+		ReferenceData:  a.ReferenceData,
+	}
+	return b.AddOutput(out)
+}
+
+func init() {
+	actionDecoders["control_address"] = decodeControlAddressAction
+}
+
+func decodeControlAddressAction(data []byte) (Action, error) {
+	a := new(ControlAddressAction)
+	err := json.Unmarshal(data, a)
+	return a, err
+}
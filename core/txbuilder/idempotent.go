@@ -0,0 +1,59 @@
+package txbuilder
+
+import (
+	"context"
+	"time"
+
+	"chain/protocol"
+	"chain/protocol/bc"
+	"chain/sync/idempotency"
+)
+
+// clientTokenTTL bounds how long a ClientToken is honored. A retry
+// arriving after this window builds and submits the transaction again
+// rather than replaying a stale result.
+const clientTokenTTL = 5 * time.Minute
+
+// idempotencyGroup coalesces concurrent Build/FinalizeTx calls that share a
+// ClientToken and caches their result for clientTokenTTL, so a client
+// retrying after a network timeout doesn't double-reserve inputs or
+// double-issue an asset.
+var idempotencyGroup idempotency.Group
+
+// BuildWithClientToken is Build, made idempotent by clientToken. Two
+// calls presenting the same non-empty clientToken within
+// clientTokenTTL return the same Template (and share a single
+// in-flight build if they race); an empty clientToken disables
+// idempotency and always builds a fresh Template.
+func BuildWithClientToken(ctx context.Context, tpl *Template, actions []Action, maxTime *time.Time, clientToken string) (*Template, error) {
+	if clientToken == "" {
+		return Build(ctx, tpl, actions, maxTime)
+	}
+
+	v, err := idempotencyGroup.Do("build:"+clientToken, clientTokenTTL, func() (interface{}, error) {
+		return Build(ctx, tpl, actions, maxTime)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*Template), nil
+}
+
+// FinalizeTxWithClientToken is FinalizeTx, made idempotent by
+// clientToken. Two calls presenting the same non-empty clientToken
+// within clientTokenTTL return the same submitted *bc.Tx instead of
+// resubmitting (and potentially double-spending the template's
+// reservations); an empty clientToken disables idempotency.
+func FinalizeTxWithClientToken(ctx context.Context, fc *protocol.FC, tpl *Template, clientToken string) (*bc.Tx, error) {
+	if clientToken == "" {
+		return FinalizeTx(ctx, fc, tpl)
+	}
+
+	v, err := idempotencyGroup.Do("finalize:"+clientToken, clientTokenTTL, func() (interface{}, error) {
+		return FinalizeTx(ctx, fc, tpl)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return v.(*bc.Tx), nil
+}
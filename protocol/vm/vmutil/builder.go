@@ -0,0 +1,46 @@
+// Package vmutil contains utilities for building programs executable by
+// the Chain VM, to be used in control programs and transaction
+// witnesses.
+package vmutil
+
+import "chain/errors"
+
+// ErrBadValue is returned by the builder helpers in this package when
+// given input outside the range or shape they require.
+var ErrBadValue = errors.New("bad value")
+
+// Builder facilitates the construction of Chain VM programs by
+// appending opcodes and data pushes in sequence.
+type Builder struct {
+	program []byte
+}
+
+// NewBuilder returns a new, empty Builder.
+func NewBuilder() *Builder {
+	return new(Builder)
+}
+
+// AddOp appends a single opcode to the program being built.
+func (b *Builder) AddOp(op byte) *Builder {
+	b.program = append(b.program, op)
+	return b
+}
+
+// AddData appends a data push of data to the program being built,
+// choosing the shortest well-formed pushdata encoding for its length.
+func (b *Builder) AddData(data []byte) *Builder {
+	b.program = append(b.program, pushDataBytes(data)...)
+	return b
+}
+
+// AddInt64 appends a push of the minimal encoding of n to the program
+// being built.
+func (b *Builder) AddInt64(n int64) *Builder {
+	b.program = append(b.program, pushInt64Bytes(n)...)
+	return b
+}
+
+// Build returns the assembled program.
+func (b *Builder) Build() ([]byte, error) {
+	return b.program, nil
+}
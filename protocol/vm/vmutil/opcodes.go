@@ -0,0 +1,53 @@
+package vmutil
+
+// Opcodes used by the programs this package builds. These mirror the
+// Chain VM instruction set; only the subset needed by the control
+// programs in this package is declared here.
+const (
+	OP_DUP         = 0x19
+	OP_SHA3        = 0xa8
+	OP_EQUALVERIFY = 0x88
+	OP_TXSIGHASH   = 0xae
+	OP_SWAP        = 0x7c
+	OP_CHECKSIG    = 0xac
+	OP_VERIFY      = 0x69
+
+	// OP_PUSHDATA1/2/4 precede a length prefix of the given byte width
+	// for pushes too long to encode with a single small-push opcode.
+	OP_PUSHDATA1 = 0x4c
+	OP_PUSHDATA2 = 0x4d
+	OP_PUSHDATA4 = 0x4e
+)
+
+// pushDataBytes returns the minimal-length opcode sequence that pushes
+// data onto the VM data stack.
+func pushDataBytes(data []byte) []byte {
+	var result []byte
+	switch {
+	case len(data) < OP_PUSHDATA1:
+		result = append(result, byte(len(data)))
+	case len(data) <= 0xff:
+		result = append(result, OP_PUSHDATA1, byte(len(data)))
+	case len(data) <= 0xffff:
+		result = append(result, OP_PUSHDATA2, byte(len(data)), byte(len(data)>>8))
+	default:
+		n := len(data)
+		result = append(result, OP_PUSHDATA4, byte(n), byte(n>>8), byte(n>>16), byte(n>>24))
+	}
+	return append(result, data...)
+}
+
+// pushInt64Bytes returns the opcode sequence that pushes the minimal
+// big-endian encoding of n onto the VM data stack.
+func pushInt64Bytes(n int64) []byte {
+	if n == 0 {
+		return []byte{0x00}
+	}
+	u := uint64(n)
+	var data []byte
+	for u > 0 {
+		data = append([]byte{byte(u)}, data...)
+		u >>= 8
+	}
+	return pushDataBytes(data)
+}
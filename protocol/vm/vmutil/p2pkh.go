@@ -0,0 +1,29 @@
+package vmutil
+
+import "chain/errors"
+
+// PubKeyHashSize is the length in bytes of a RIPEMD160(SHA256(pubkey))
+// hash used in a P2PKHProgram.
+const PubKeyHashSize = 20
+
+// P2PKHProgram returns a control program that requires a single
+// signature from the public key whose hash is hash. The spender's
+// witness supplies the signature and public key; the program checks
+// the key against hash, then verifies the signature against the
+// transaction sighash.
+func P2PKHProgram(hash []byte) ([]byte, error) {
+	if len(hash) != PubKeyHashSize {
+		return nil, errors.WithDetailf(ErrBadValue, "pubkey hash length %d, want %d", len(hash), PubKeyHashSize)
+	}
+
+	builder := NewBuilder()
+	builder.AddOp(OP_DUP)
+	builder.AddOp(OP_SHA3)
+	builder.AddData(hash)
+	builder.AddOp(OP_EQUALVERIFY)
+	builder.AddOp(OP_TXSIGHASH)
+	builder.AddOp(OP_SWAP)
+	builder.AddOp(OP_CHECKSIG)
+	builder.AddOp(OP_VERIFY)
+	return builder.Build()
+}
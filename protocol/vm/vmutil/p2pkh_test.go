@@ -0,0 +1,48 @@
+package vmutil
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestP2PKHProgram(t *testing.T) {
+	hash := bytes.Repeat([]byte{0xab}, PubKeyHashSize)
+
+	got, err := P2PKHProgram(hash)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []byte{OP_DUP, OP_SHA3}
+	want = append(want, pushDataBytes(hash)...)
+	want = append(want, OP_EQUALVERIFY, OP_TXSIGHASH, OP_SWAP, OP_CHECKSIG, OP_VERIFY)
+
+	if !bytes.Equal(got, want) {
+		t.Errorf("P2PKHProgram(%x) = %x, want %x", hash, got, want)
+	}
+}
+
+func TestP2PKHProgramBadHashLength(t *testing.T) {
+	for _, n := range []int{0, 19, 21, 32} {
+		_, err := P2PKHProgram(make([]byte, n))
+		if err == nil {
+			t.Errorf("P2PKHProgram(%d-byte hash) = nil error, want error", n)
+		}
+	}
+}
+
+func TestBuilderAddData(t *testing.T) {
+	cases := []struct {
+		data []byte
+		want []byte
+	}{
+		{[]byte{}, []byte{0x00}},
+		{[]byte{0x01, 0x02, 0x03}, []byte{0x03, 0x01, 0x02, 0x03}},
+	}
+	for _, c := range cases {
+		got := NewBuilder().AddData(c.data).program
+		if !bytes.Equal(got, c.want) {
+			t.Errorf("AddData(%x) = %x, want %x", c.data, got, c.want)
+		}
+	}
+}
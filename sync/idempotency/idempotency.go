@@ -0,0 +1,90 @@
+// Package idempotency provides a mechanism for coalescing concurrent
+// calls that share a key and for caching their result for a short
+// window, so that retries of the same logical request (for example, a
+// client resubmitting after a network timeout) are served from the
+// first call's result instead of re-executing.
+package idempotency
+
+import (
+	"sync"
+	"time"
+)
+
+// call represents a single in-flight or recently completed invocation
+// of fn for a given key. val, err, and expiry are only valid once done
+// is true; all three are set by the owning goroutine, under Group.mu,
+// before it calls wg.Done(). Waiting callers read val and err only
+// after wg.Wait() returns, which orders those reads after the writes.
+type call struct {
+	wg     sync.WaitGroup
+	val    interface{}
+	err    error
+	expiry time.Time
+	done   bool
+}
+
+// Group coalesces concurrent calls sharing a key into a single
+// execution of fn, and caches the result until it expires.
+//
+// The zero value is ready to use.
+type Group struct {
+	mu    sync.Mutex
+	calls map[string]*call
+}
+
+// Do executes and returns the result of fn, making sure only one
+// execution is in flight for a given key at a time. If a call for key
+// is already in flight, or completed less than ttl ago, Do waits for it
+// (or returns its cached result immediately) instead of invoking fn
+// again.
+//
+// A ttl of zero disables caching: the result is discarded as soon as
+// the call completes, though concurrent callers still share the single
+// in-flight execution.
+func (g *Group) Do(key string, ttl time.Duration, fn func() (interface{}, error)) (interface{}, error) {
+	g.mu.Lock()
+	g.sweepLocked()
+
+	if c, ok := g.calls[key]; ok {
+		g.mu.Unlock()
+		c.wg.Wait()
+		return c.val, c.err
+	}
+
+	c := new(call)
+	c.wg.Add(1)
+	if g.calls == nil {
+		g.calls = make(map[string]*call)
+	}
+	g.calls[key] = c
+	g.mu.Unlock()
+
+	val, err := fn()
+
+	g.mu.Lock()
+	c.val, c.err = val, err
+	c.expiry = time.Now().Add(ttl)
+	c.done = true
+	// Only a successful result is worth coalescing future callers onto;
+	// a failure (e.g. a transient DB/network error) should let the very
+	// next caller with this key try again instead of replaying the same
+	// error for the rest of ttl.
+	if err != nil || ttl <= 0 {
+		delete(g.calls, key)
+	}
+	g.mu.Unlock()
+
+	c.wg.Done()
+	return val, err
+}
+
+// sweepLocked removes entries whose result has expired. Callers must
+// hold g.mu. In-flight calls (done == false) are never swept.
+func (g *Group) sweepLocked() {
+	now := time.Now()
+	for key, c := range g.calls {
+		if c.done && now.After(c.expiry) {
+			delete(g.calls, key)
+		}
+	}
+}
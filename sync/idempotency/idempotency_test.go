@@ -0,0 +1,138 @@
+package idempotency
+
+import (
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestDoCoalescesConcurrentCallers(t *testing.T) {
+	var g Group
+	var calls int32
+
+	const n = 50
+	var wg sync.WaitGroup
+	results := make([]int, n)
+	wg.Add(n)
+	for i := 0; i < n; i++ {
+		i := i
+		go func() {
+			defer wg.Done()
+			v, err := g.Do("key", time.Minute, func() (interface{}, error) {
+				atomic.AddInt32(&calls, 1)
+				time.Sleep(10 * time.Millisecond)
+				return 42, nil
+			})
+			if err != nil {
+				t.Error(err)
+			}
+			results[i] = v.(int)
+		}()
+	}
+	wg.Wait()
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("fn ran %d times, want 1", got)
+	}
+	for i, r := range results {
+		if r != 42 {
+			t.Errorf("results[%d] = %d, want 42", i, r)
+		}
+	}
+}
+
+func TestDoCachesWithinTTL(t *testing.T) {
+	var g Group
+	var calls int32
+
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	}
+
+	_, err := g.Do("key", time.Hour, fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = g.Do("key", time.Hour, fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 1 {
+		t.Errorf("fn ran %d times within ttl, want 1", got)
+	}
+}
+
+func TestDoReexecutesAfterTTL(t *testing.T) {
+	var g Group
+	var calls int32
+
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	}
+
+	_, err := g.Do("key", time.Millisecond, fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+	time.Sleep(5 * time.Millisecond)
+	_, err = g.Do("key", time.Millisecond, fn)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("fn ran %d times across ttl expiry, want 2", got)
+	}
+}
+
+func TestDoDistinctKeysRunIndependently(t *testing.T) {
+	var g Group
+	var calls int32
+
+	fn := func() (interface{}, error) {
+		atomic.AddInt32(&calls, 1)
+		return nil, nil
+	}
+
+	g.Do("a", time.Minute, fn)
+	g.Do("b", time.Minute, fn)
+
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("fn ran %d times for distinct keys, want 2", got)
+	}
+}
+
+func TestDoDoesNotCacheError(t *testing.T) {
+	var g Group
+	var calls int32
+
+	wantErr := errors.New("transient failure")
+	fn := func() (interface{}, error) {
+		n := atomic.AddInt32(&calls, 1)
+		if n == 1 {
+			return nil, wantErr
+		}
+		return 42, nil
+	}
+
+	_, err := g.Do("key", time.Hour, fn)
+	if err != wantErr {
+		t.Fatalf("first call err = %v, want %v", err, wantErr)
+	}
+
+	v, err := g.Do("key", time.Hour, fn)
+	if err != nil {
+		t.Fatalf("second call err = %v, want nil", err)
+	}
+	if v.(int) != 42 {
+		t.Errorf("second call result = %v, want 42", v)
+	}
+	if got := atomic.LoadInt32(&calls); got != 2 {
+		t.Errorf("fn ran %d times, want 2 (error result must not be cached)", got)
+	}
+}
@@ -0,0 +1,155 @@
+// Package bench holds throughput benchmarks for the full issue/spend/
+// commit path, built on the fixtures in core/asset/assettest. They
+// exist to measure the end-to-end cost of changes to reservation,
+// signing, or block commit under load that isn't visible from a single
+// transaction's unit tests.
+package bench
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"chain/core/asset/assettest"
+	"chain/core/generator"
+	"chain/database/pg"
+	"chain/database/pg/pgtest"
+	"chain/protocol"
+	"chain/protocol/bc"
+	"chain/protocol/mempool"
+	"chain/protocol/memstore"
+)
+
+// poolBackend selects the mempool implementation the benchmarks run
+// against, so storage-layer changes (memstore vs. Postgres) can be
+// compared directly: go test -bench . -bench.pool=postgres
+var poolBackend = flag.String("bench.pool", "memstore", "mempool backend to benchmark: memstore or postgres")
+
+const (
+	numAccounts  = 10
+	fundPerAsset = 1 << 32
+
+	// commitEvery controls how often, in transactions, the benchmark
+	// loop cuts a new block, so the timed work includes a realistic
+	// share of block-commit cost instead of running entirely against
+	// the mempool.
+	commitEvery = 50
+)
+
+// benchChain holds everything a single benchmark needs to build and
+// commit transactions: the context (carrying the test DB every
+// assettest call needs), the FC/generator pair, a TxGenerator for
+// building transactions, and the pre-funded accounts to spend from.
+type benchChain struct {
+	ctx context.Context
+	fc  *protocol.FC
+	gen *generator.Generator
+	txg *assettest.TxGenerator
+
+	accounts []string
+	assetIDs []bc.AssetID
+}
+
+func newPool(b *testing.B, ctx context.Context) protocol.Pool {
+	if *poolBackend == "postgres" {
+		return pgtest.NewPool(b, pg.FromContext(ctx))
+	}
+	return mempool.New()
+}
+
+// setupBenchChain pre-funds numAssets assets across numAccounts
+// accounts in a single warm-up block, so the timed portion of each
+// benchmark only pays for transfers, not issuance.
+func setupBenchChain(b *testing.B, numAssets int) *benchChain {
+	ctx, cleanup := pgtest.NewContext(b)
+	b.Cleanup(cleanup)
+
+	fc, blockGenerator, err := assettest.InitializeSigningGenerator(ctx, memstore.New(), newPool(b, ctx))
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	ch := &benchChain{
+		ctx: ctx,
+		fc:  fc,
+		gen: blockGenerator,
+		txg: assettest.NewTxGenerator(fc, nil),
+	}
+
+	for i := 0; i < numAccounts; i++ {
+		ch.accounts = append(ch.accounts, ch.txg.CreateAccount(ctx, b, 1, "", nil))
+	}
+	for i := 0; i < numAssets; i++ {
+		assetID := ch.txg.DefineAsset(ctx, b, 1, nil, "", nil)
+		ch.assetIDs = append(ch.assetIDs, assetID)
+		for _, accountID := range ch.accounts {
+			ch.txg.Issue(assetID, fundPerAsset, accountID)
+			ch.txg.Finalize(ctx, b)
+		}
+	}
+	ch.commitBlock(b)
+
+	return ch
+}
+
+// commitBlock makes and commits a block covering everything submitted
+// to the mempool so far, so the benchmark includes state/UTXO indexing
+// costs alongside signing and reservation.
+func (ch *benchChain) commitBlock(b *testing.B) {
+	block, snapshot, err := ch.gen.MakeBlock(ch.ctx)
+	if err != nil {
+		b.Fatal(err)
+	}
+	err = ch.fc.CommitBlock(ch.ctx, block, snapshot)
+	if err != nil {
+		b.Fatal(err)
+	}
+}
+
+func runTransferBenchmark(b *testing.B, numAssets int) {
+	ch := setupBenchChain(b, numAssets)
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		from := ch.accounts[i%len(ch.accounts)]
+		to := ch.accounts[(i+1)%len(ch.accounts)]
+		for _, assetID := range ch.assetIDs {
+			ch.txg.Transfer(assetID, 1, from, to)
+		}
+		ch.txg.Finalize(ch.ctx, b)
+
+		if i%commitEvery == commitEvery-1 {
+			ch.commitBlock(b)
+		}
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "tx/s")
+}
+
+func BenchmarkChain_Issue(b *testing.B) {
+	ch := setupBenchChain(b, 0)
+	assetID := ch.txg.DefineAsset(ch.ctx, b, 1, nil, "", nil)
+	accountID := ch.accounts[0]
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		ch.txg.Issue(assetID, 1, accountID)
+		ch.txg.Finalize(ch.ctx, b)
+
+		if i%commitEvery == commitEvery-1 {
+			ch.commitBlock(b)
+		}
+	}
+	b.StopTimer()
+
+	b.ReportMetric(float64(b.N)/b.Elapsed().Seconds(), "tx/s")
+}
+
+func BenchmarkChain_Transfer_SingleAsset(b *testing.B) {
+	runTransferBenchmark(b, 1)
+}
+
+func BenchmarkChain_Transfer_MultiAsset(b *testing.B) {
+	runTransferBenchmark(b, 5)
+}